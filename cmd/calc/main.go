@@ -0,0 +1,62 @@
+// Command calc evaluates arithmetic expressions from the command line.
+//
+// With -e, it evaluates a single expression and exits:
+//
+//	calc -e "2 + 3 * (4 - 1) / 2"
+//
+// Without -e, it reads one expression per line from stdin until EOF,
+// printing the result of each.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ssharifzoda/lessons/lesson12/calculator"
+)
+
+func main() {
+	expr := flag.String("e", "", "expression to evaluate (default: read from stdin)")
+	flag.Parse()
+
+	if *expr != "" {
+		if err := evalAndPrint(os.Stdout, *expr); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := repl(os.Stdin, os.Stdout); err != nil && err != io.EOF {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// repl reads one expression per line from r, printing each result (or
+// error) to w until r is exhausted.
+func repl(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if err := evalAndPrint(w, line); err != nil {
+			fmt.Fprintln(w, "error:", err)
+		}
+	}
+	return scanner.Err()
+}
+
+func evalAndPrint(w io.Writer, expr string) error {
+	result, err := calculator.Eval(expr)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, result)
+	return err
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRepl(t *testing.T) {
+	in := strings.NewReader("2 + 2\n1 / 0\nsqrt(9)\n")
+	var out bytes.Buffer
+
+	if err := repl(in, &out); err != nil {
+		t.Fatalf("repl: %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{"4", "error:", "3"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output %q does not contain %q", got, want)
+		}
+	}
+}
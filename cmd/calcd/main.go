@@ -0,0 +1,43 @@
+// Command calcd serves the calculator package over HTTP/JSON.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Addr:    *addr,
+		Handler: withRecovery(withLogging(newMux())),
+	}
+
+	go func() {
+		log.Printf("calcd listening on %s", *addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("calcd: listen: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Print("calcd: shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Fatalf("calcd: graceful shutdown failed: %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+// server.go
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ssharifzoda/lessons/lesson12/calculator"
+)
+
+// evalRequest is the body accepted by POST /v1/eval. Either Expr or Op must
+// be set.
+type evalRequest struct {
+	Expr string  `json:"expr,omitempty"`
+	Op   string  `json:"op,omitempty"`
+	A    float64 `json:"a,omitempty"`
+	B    float64 `json:"b,omitempty"`
+}
+
+type evalResponse struct {
+	Result float64 `json:"result"`
+	Error  string  `json:"error,omitempty"`
+}
+
+type opsResponse struct {
+	Ops []string `json:"ops"`
+}
+
+// newMux builds the calcd route table.
+func newMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/eval", handleEval)
+	mux.HandleFunc("/v1/ops", handleOps)
+	mux.HandleFunc("/healthz", handleHealthz)
+	return mux
+}
+
+func handleEval(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req evalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, evalResponse{Error: "invalid request body"})
+		return
+	}
+
+	var (
+		result float64
+		err    error
+	)
+	switch {
+	case req.Expr != "":
+		result, err = calculator.Eval(req.Expr)
+	case req.Op != "":
+		result, err = calculator.Calculate(req.Op, req.A, req.B)
+	default:
+		writeJSON(w, http.StatusBadRequest, evalResponse{Error: "request must set expr or op"})
+		return
+	}
+
+	if err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, evalResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, evalResponse{Result: result})
+}
+
+func handleOps(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, opsResponse{Ops: calculator.Operators()})
+}
+
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// withLogging logs the method, path, status, and duration of every request.
+func withLogging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		log.Printf("%s %s %d %s", r.Method, r.URL.Path, rec.status, time.Since(start))
+	})
+}
+
+// withRecovery turns a panic in next into a 500 response instead of
+// crashing the server.
+func withRecovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				log.Printf("panic recovered: %v", rec)
+				writeJSON(w, http.StatusInternalServerError, evalResponse{Error: "internal error"})
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
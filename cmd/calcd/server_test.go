@@ -0,0 +1,134 @@
+// server_test.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandleEvalExpr(t *testing.T) {
+	srv := httptest.NewServer(withRecovery(withLogging(newMux())))
+	defer srv.Close()
+
+	body, _ := json.Marshal(evalRequest{Expr: "2 + 2"})
+	resp, err := http.Post(srv.URL+"/v1/eval", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /v1/eval: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d; expected %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var got evalResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Result != 4 {
+		t.Errorf("result = %v; expected 4", got.Result)
+	}
+}
+
+func TestHandleEvalZeroResult(t *testing.T) {
+	srv := httptest.NewServer(withRecovery(withLogging(newMux())))
+	defer srv.Close()
+
+	body, _ := json.Marshal(evalRequest{Expr: "2 - 2"})
+	resp, err := http.Post(srv.URL+"/v1/eval", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /v1/eval: %v", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+	if !strings.Contains(string(raw), `"result":0`) {
+		t.Errorf("body = %s; expected it to include \"result\":0", raw)
+	}
+}
+
+func TestHandleEvalOp(t *testing.T) {
+	srv := httptest.NewServer(withRecovery(withLogging(newMux())))
+	defer srv.Close()
+
+	body, _ := json.Marshal(evalRequest{Op: "add", A: 1, B: 2})
+	resp, err := http.Post(srv.URL+"/v1/eval", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /v1/eval: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var got evalResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Result != 3 {
+		t.Errorf("result = %v; expected 3", got.Result)
+	}
+}
+
+func TestHandleEvalDivideByZero(t *testing.T) {
+	srv := httptest.NewServer(withRecovery(withLogging(newMux())))
+	defer srv.Close()
+
+	body, _ := json.Marshal(evalRequest{Expr: "1 / 0"})
+	resp, err := http.Post(srv.URL+"/v1/eval", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /v1/eval: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d; expected %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+
+	var got evalResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Error == "" {
+		t.Error("expected a non-empty error message")
+	}
+}
+
+func TestHandleOps(t *testing.T) {
+	srv := httptest.NewServer(withRecovery(withLogging(newMux())))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/v1/ops")
+	if err != nil {
+		t.Fatalf("GET /v1/ops: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var got opsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(got.Ops) == 0 {
+		t.Error("expected at least one registered operator")
+	}
+}
+
+func TestHandleHealthz(t *testing.T) {
+	srv := httptest.NewServer(newMux())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/healthz")
+	if err != nil {
+		t.Fatalf("GET /healthz: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d; expected %d", resp.StatusCode, http.StatusOK)
+	}
+}
@@ -0,0 +1,106 @@
+// calc.go
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ErrDivideByZero is returned when a division or modulo operation is
+// attempted with a zero divisor.
+var ErrDivideByZero = errors.New("calculator: division by zero")
+
+// ErrNegativeSqrt is returned when Sqrt is called with a negative operand.
+var ErrNegativeSqrt = errors.New("calculator: square root of negative number")
+
+// ErrUnknownOp is returned by Calculate when op is not registered.
+var ErrUnknownOp = errors.New("calculator: unknown operator")
+
+// Add returns a + b.
+func Add(a, b float64) float64 {
+	return a + b
+}
+
+// Subtract returns a - b.
+func Subtract(a, b float64) float64 {
+	return a - b
+}
+
+// Multiply returns a * b.
+func Multiply(a, b float64) float64 {
+	return a * b
+}
+
+// Divide returns a / b, or ErrDivideByZero if b is zero.
+func Divide(a, b float64) (float64, error) {
+	if b == 0 {
+		return 0, ErrDivideByZero
+	}
+	return a / b, nil
+}
+
+// Modulo returns the floating-point remainder of a / b, or ErrDivideByZero
+// if b is zero.
+func Modulo(a, b float64) (float64, error) {
+	if b == 0 {
+		return 0, ErrDivideByZero
+	}
+	return math.Mod(a, b), nil
+}
+
+// Power returns a raised to the power of b.
+func Power(a, b float64) float64 {
+	return math.Pow(a, b)
+}
+
+// Sqrt returns the square root of a, or ErrNegativeSqrt if a is negative.
+func Sqrt(a float64) (float64, error) {
+	if a < 0 {
+		return 0, ErrNegativeSqrt
+	}
+	return math.Sqrt(a), nil
+}
+
+// opFunc is the signature shared by every operator registered in the
+// package-level registry, so Calculate can dispatch to any of them by name.
+type opFunc func(a, b float64) (float64, error)
+
+// registry maps operator names to their implementation. Callers may add
+// their own operators with Register.
+var registry = map[string]opFunc{
+	"add": func(a, b float64) (float64, error) { return Add(a, b), nil },
+	"sub": func(a, b float64) (float64, error) { return Subtract(a, b), nil },
+	"mul": func(a, b float64) (float64, error) { return Multiply(a, b), nil },
+	"div": Divide,
+	"mod": Modulo,
+	"pow": func(a, b float64) (float64, error) { return Power(a, b), nil },
+}
+
+// Register adds or replaces the operator named op in the registry used by
+// Calculate.
+func Register(op string, fn func(a, b float64) (float64, error)) {
+	registry[op] = fn
+}
+
+// Calculate dispatches to the operator registered under op, passing a and b.
+// It returns ErrUnknownOp if op has not been registered.
+func Calculate(op string, a, b float64) (float64, error) {
+	fn, ok := registry[op]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownOp, op)
+	}
+	return fn(a, b)
+}
+
+// Operators returns the names of every operator currently registered,
+// sorted alphabetically.
+func Operators() []string {
+	ops := make([]string, 0, len(registry))
+	for op := range registry {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+	return ops
+}
@@ -1,24 +1,207 @@
-// calculator_test.go
+// calc_test.go
 package calculator
 
-import "testing"
+import (
+	"errors"
+	"math"
+	"testing"
+)
 
 // Тест функции Add
 func TestAdd(t *testing.T) {
 	result := Add(2, 3)
-	expected := 5
+	expected := 5.0
 
 	if result != expected {
-		t.Errorf("Add(2, 3) = %d; expected %d", result, expected)
+		t.Errorf("Add(2, 3) = %v; expected %v", result, expected)
 	}
 }
 
 // Тест функции Multiply
 func TestMultiply(t *testing.T) {
 	result := Multiply(4, 5)
-	expected := 20
+	expected := 20.0
 
 	if result != expected {
-		t.Errorf("Multiply(4, 5) = %d; expected %d", result, expected)
+		t.Errorf("Multiply(4, 5) = %v; expected %v", result, expected)
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	cases := []struct {
+		a, b, want float64
+	}{
+		{5, 3, 2},
+		{0, 0, 0},
+		{-1, -1, 0},
+		{math.MaxFloat64, math.MaxFloat64, 0},
+	}
+
+	for _, c := range cases {
+		if got := Subtract(c.a, c.b); got != c.want {
+			t.Errorf("Subtract(%v, %v) = %v; expected %v", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestDivide(t *testing.T) {
+	cases := []struct {
+		name    string
+		a, b    float64
+		want    float64
+		wantErr error
+	}{
+		{"basic", 10, 2, 5, nil},
+		{"by zero", 1, 0, 0, ErrDivideByZero},
+		{"overflow", math.MaxFloat64, 0.5, math.Inf(1), nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Divide(c.a, c.b)
+			if !errors.Is(err, c.wantErr) {
+				t.Fatalf("Divide(%v, %v) error = %v; expected %v", c.a, c.b, err, c.wantErr)
+			}
+			if c.wantErr == nil && got != c.want {
+				t.Errorf("Divide(%v, %v) = %v; expected %v", c.a, c.b, got, c.want)
+			}
+		})
+	}
+}
+
+func TestModulo(t *testing.T) {
+	cases := []struct {
+		name    string
+		a, b    float64
+		want    float64
+		wantErr error
+	}{
+		{"basic", 10, 3, 1, nil},
+		{"by zero", 1, 0, 0, ErrDivideByZero},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Modulo(c.a, c.b)
+			if !errors.Is(err, c.wantErr) {
+				t.Fatalf("Modulo(%v, %v) error = %v; expected %v", c.a, c.b, err, c.wantErr)
+			}
+			if c.wantErr == nil && got != c.want {
+				t.Errorf("Modulo(%v, %v) = %v; expected %v", c.a, c.b, got, c.want)
+			}
+		})
+	}
+}
+
+func TestPower(t *testing.T) {
+	cases := []struct {
+		a, b, want float64
+	}{
+		{2, 10, 1024},
+		{2, 0, 1},
+		{0, 0, 1},
+	}
+
+	for _, c := range cases {
+		if got := Power(c.a, c.b); got != c.want {
+			t.Errorf("Power(%v, %v) = %v; expected %v", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	cases := []struct {
+		name    string
+		a       float64
+		want    float64
+		wantErr error
+	}{
+		{"perfect square", 16, 4, nil},
+		{"zero", 0, 0, nil},
+		{"negative", -4, 0, ErrNegativeSqrt},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Sqrt(c.a)
+			if !errors.Is(err, c.wantErr) {
+				t.Fatalf("Sqrt(%v) error = %v; expected %v", c.a, err, c.wantErr)
+			}
+			if c.wantErr == nil && got != c.want {
+				t.Errorf("Sqrt(%v) = %v; expected %v", c.a, got, c.want)
+			}
+		})
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	cases := []struct {
+		name    string
+		op      string
+		a, b    float64
+		want    float64
+		wantErr error
+	}{
+		{"add", "add", 2, 3, 5, nil},
+		{"div by zero", "div", 1, 0, 0, ErrDivideByZero},
+		{"unknown op", "xor", 1, 2, 0, ErrUnknownOp},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Calculate(c.op, c.a, c.b)
+			if !errors.Is(err, c.wantErr) {
+				t.Fatalf("Calculate(%q, %v, %v) error = %v; expected %v", c.op, c.a, c.b, err, c.wantErr)
+			}
+			if c.wantErr == nil && got != c.want {
+				t.Errorf("Calculate(%q, %v, %v) = %v; expected %v", c.op, c.a, c.b, got, c.want)
+			}
+		})
+	}
+}
+
+func TestCalculateNaN(t *testing.T) {
+	got, err := Calculate("pow", math.Inf(1), 0)
+	if err != nil {
+		t.Fatalf("Calculate(pow, +Inf, 0) unexpected error: %v", err)
+	}
+	if math.IsNaN(got) {
+		t.Errorf("Calculate(pow, +Inf, 0) = NaN; expected a defined value")
+	}
+
+	got, err = Calculate("pow", -1, 0.5)
+	if err != nil {
+		t.Fatalf("Calculate(pow, -1, 0.5) unexpected error: %v", err)
+	}
+	if !math.IsNaN(got) {
+		t.Errorf("Calculate(pow, -1, 0.5) = %v; expected NaN", got)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("double", func(a, b float64) (float64, error) { return a * 2, nil })
+
+	got, err := Calculate("double", 21, 0)
+	if err != nil {
+		t.Fatalf("Calculate(double, 21, 0) unexpected error: %v", err)
+	}
+	if got != 42 {
+		t.Errorf("Calculate(double, 21, 0) = %v; expected 42", got)
+	}
+}
+
+func TestOperators(t *testing.T) {
+	ops := Operators()
+	for _, want := range []string{"add", "sub", "mul", "div", "mod", "pow"} {
+		found := false
+		for _, op := range ops {
+			if op == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Operators() = %v; expected it to contain %q", ops, want)
+		}
 	}
 }
@@ -0,0 +1,343 @@
+// eval.go
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrUnbalancedParens is returned by Eval when parentheses in the
+// expression do not match.
+var ErrUnbalancedParens = errors.New("calculator: unbalanced parentheses")
+
+// ErrUnknownToken is returned by Eval when the expression contains a
+// character it cannot tokenize or an identifier it cannot resolve, or is
+// otherwise malformed (for example, an operator missing an operand).
+var ErrUnknownToken = errors.New("calculator: unknown token")
+
+// tokenKind classifies a token produced by the tokenizer.
+type tokenKind int
+
+const (
+	tokenNumber tokenKind = iota
+	tokenIdent
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	argc int // number of arguments, set on tokenIdent when it is a function call
+}
+
+// precedence and associativity of the binary operators Eval understands.
+// "u-" is the rewritten form of a unary minus (see tokenize): it shares
+// its precedence with "^" and, being a prefix operator, never pops the
+// operators before it (see toRPN), so "2 * -3" parses as "2 * (0 - 3)",
+// "2 ^ -3" as "2 ^ (0 - 3)", and "-2 ^ 2" as "0 - (2 ^ 2)" = -4, matching
+// conventional calculator precedence.
+var precedence = map[string]int{
+	"+":  2,
+	"-":  2,
+	"*":  3,
+	"/":  3,
+	"%":  3,
+	"^":  4,
+	"u-": 4,
+}
+
+var rightAssoc = map[string]bool{
+	"^": true,
+}
+
+// funcs are the named functions Eval can call. They are kept in this
+// table, apart from the operator registry used by Calculate, so an
+// operator added with Register cannot be called by name from Eval. Each
+// takes one or more arguments and returns a single value.
+var funcs = map[string]func(args ...float64) (float64, error){
+	"sqrt": func(args ...float64) (float64, error) { return Sqrt(args[0]) },
+	"abs": func(args ...float64) (float64, error) {
+		if args[0] < 0 {
+			return -args[0], nil
+		}
+		return args[0], nil
+	},
+	"min": func(args ...float64) (float64, error) {
+		m := args[0]
+		for _, a := range args[1:] {
+			if a < m {
+				m = a
+			}
+		}
+		return m, nil
+	},
+	"max": func(args ...float64) (float64, error) {
+		m := args[0]
+		for _, a := range args[1:] {
+			if a > m {
+				m = a
+			}
+		}
+		return m, nil
+	},
+}
+
+// tokenize splits expr into tokens, rewriting a unary minus into the binary
+// form "0 - x" whenever a '-' follows an operator, a '(', a comma, or the
+// start of the expression.
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+	expectOperand := true
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "("})
+			expectOperand = true
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")"})
+			expectOperand = false
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ","})
+			expectOperand = true
+			i++
+		case strings.ContainsRune("+-*/%^", r):
+			if r == '-' && expectOperand {
+				tokens = append(tokens, token{kind: tokenNumber, text: "0", num: 0})
+				tokens = append(tokens, token{kind: tokenOp, text: "u-"})
+				expectOperand = true
+				i++
+				continue
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: string(r)})
+			expectOperand = true
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			text := string(runes[start:i])
+			num, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrUnknownToken, text)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, num: num})
+			expectOperand = false
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i])})
+			expectOperand = false
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownToken, string(r))
+		}
+	}
+	return tokens, nil
+}
+
+// parenFrame tracks, for one level of open parentheses, whether it was
+// opened by a function call and how many arguments have been seen so far
+// (via commas), so the matching function token can be tagged with its
+// arity once the call closes.
+type parenFrame struct {
+	isCall bool
+	argc   int
+}
+
+// toRPN converts infix tokens to reverse Polish notation using Dijkstra's
+// shunting-yard algorithm. A bare identifier (no following '(') is treated
+// as a variable and flows straight to the output, like a number; an
+// identifier followed by '(' is treated as a function call and held on the
+// operator stack until its matching ')'.
+func toRPN(tokens []token) ([]token, error) {
+	var output, stack []token
+	var parens []parenFrame
+
+	popOp := func() token {
+		n := len(stack) - 1
+		top := stack[n]
+		stack = stack[:n]
+		return top
+	}
+
+	for i, tok := range tokens {
+		switch tok.kind {
+		case tokenNumber:
+			output = append(output, tok)
+		case tokenIdent:
+			isCall := i+1 < len(tokens) && tokens[i+1].kind == tokenLParen
+			if isCall {
+				stack = append(stack, tok)
+			} else {
+				output = append(output, tok)
+			}
+		case tokenComma:
+			for len(stack) > 0 && stack[len(stack)-1].kind != tokenLParen {
+				output = append(output, popOp())
+			}
+			if len(stack) == 0 || len(parens) == 0 {
+				return nil, ErrUnbalancedParens
+			}
+			parens[len(parens)-1].argc++
+		case tokenOp:
+			// A unary minus has no left operand of its own, so it pops
+			// nothing when it arrives.
+			for tok.text != "u-" && len(stack) > 0 && stack[len(stack)-1].kind == tokenOp {
+				top := stack[len(stack)-1]
+				if precedence[top.text] > precedence[tok.text] ||
+					(precedence[top.text] == precedence[tok.text] && !rightAssoc[tok.text]) {
+					output = append(output, popOp())
+					continue
+				}
+				break
+			}
+			stack = append(stack, tok)
+		case tokenLParen:
+			isCall := len(stack) > 0 && stack[len(stack)-1].kind == tokenIdent
+			parens = append(parens, parenFrame{isCall: isCall, argc: 1})
+			stack = append(stack, tok)
+		case tokenRParen:
+			for len(stack) > 0 && stack[len(stack)-1].kind != tokenLParen {
+				output = append(output, popOp())
+			}
+			if len(stack) == 0 || len(parens) == 0 {
+				return nil, ErrUnbalancedParens
+			}
+			popOp() // discard '('
+			frame := parens[len(parens)-1]
+			parens = parens[:len(parens)-1]
+			if frame.isCall {
+				fn := popOp()
+				fn.argc = frame.argc
+				output = append(output, fn)
+			}
+		}
+	}
+
+	for len(stack) > 0 {
+		top := popOp()
+		if top.kind == tokenLParen {
+			return nil, ErrUnbalancedParens
+		}
+		output = append(output, top)
+	}
+	return output, nil
+}
+
+// evalRPN walks rpn with a float64 stack, resolving identifiers against
+// vars and funcs.
+func evalRPN(rpn []token, vars map[string]float64) (float64, error) {
+	var stack []float64
+
+	pop := func() float64 {
+		n := len(stack) - 1
+		v := stack[n]
+		stack = stack[:n]
+		return v
+	}
+
+	for _, tok := range rpn {
+		switch tok.kind {
+		case tokenNumber:
+			stack = append(stack, tok.num)
+		case tokenOp:
+			if len(stack) < 2 {
+				return 0, fmt.Errorf("%w: not enough operands for %q", ErrUnknownToken, tok.text)
+			}
+			b, a := pop(), pop()
+			result, err := Calculate(opName(tok.text), a, b)
+			if err != nil {
+				return 0, err
+			}
+			stack = append(stack, result)
+		case tokenIdent:
+			if tok.argc > 0 {
+				fn, ok := funcs[tok.text]
+				if !ok {
+					return 0, fmt.Errorf("%w: %q", ErrUnknownToken, tok.text)
+				}
+				if len(stack) < tok.argc {
+					return 0, fmt.Errorf("%w: missing argument for %q", ErrUnknownToken, tok.text)
+				}
+				args := make([]float64, tok.argc)
+				for i := tok.argc - 1; i >= 0; i-- {
+					args[i] = pop()
+				}
+				result, err := fn(args...)
+				if err != nil {
+					return 0, err
+				}
+				stack = append(stack, result)
+				continue
+			}
+			v, ok := vars[tok.text]
+			if !ok {
+				return 0, fmt.Errorf("%w: %q", ErrUnknownToken, tok.text)
+			}
+			stack = append(stack, v)
+		}
+	}
+
+	if len(stack) != 1 {
+		return 0, fmt.Errorf("%w: malformed expression", ErrUnknownToken)
+	}
+	return stack[0], nil
+}
+
+// opName maps a shunting-yard operator symbol to its Calculate registry
+// name.
+func opName(symbol string) string {
+	switch symbol {
+	case "+":
+		return "add"
+	case "-", "u-":
+		return "sub"
+	case "*":
+		return "mul"
+	case "/":
+		return "div"
+	case "%":
+		return "mod"
+	case "^":
+		return "pow"
+	default:
+		return symbol
+	}
+}
+
+// Eval parses and evaluates an infix arithmetic expression such as
+// "2 + 3 * (4 - 1) / 2". vars, if non-nil, supplies values for identifiers
+// in the expression. An identifier directly followed by '(' is called as
+// one of the functions sqrt, abs, min, max; any other identifier is
+// substituted from vars.
+func Eval(expr string, vars ...map[string]float64) (float64, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return 0, err
+	}
+	rpn, err := toRPN(tokens)
+	if err != nil {
+		return 0, err
+	}
+	var scope map[string]float64
+	if len(vars) > 0 {
+		scope = vars[0]
+	}
+	return evalRPN(rpn, scope)
+}
@@ -0,0 +1,109 @@
+// eval_test.go
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	cases := []struct {
+		name string
+		expr string
+		vars map[string]float64
+		want float64
+	}{
+		{"precedence", "2 + 3 * (4 - 1) / 2", nil, 6.5},
+		{"power right assoc", "2 ^ 3 ^ 2", nil, 512},
+		{"unary minus", "-5 + 3", nil, -2},
+		{"unary after paren", "(-5 + 3) * -1", nil, 2},
+		{"unary minus binds looser than power", "-2 ^ 2", nil, -4},
+		{"modulo", "10 % 3", nil, 1},
+		{"variables", "x * x + 1", map[string]float64{"x": 3}, 10},
+		{"sqrt call", "sqrt(16) + 1", nil, 5},
+		{"abs call", "abs(-7)", nil, 7},
+		{"min call", "min(3, 1, 2)", nil, 1},
+		{"max call", "max(3, 1, 2)", nil, 3},
+		{"nested calls", "sqrt(max(9, 4))", nil, 3},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Eval(c.expr, c.vars)
+			if err != nil {
+				t.Fatalf("Eval(%q) unexpected error: %v", c.expr, err)
+			}
+			if got != c.want {
+				t.Errorf("Eval(%q) = %v; expected %v", c.expr, got, c.want)
+			}
+		})
+	}
+}
+
+func TestEvalNoVars(t *testing.T) {
+	got, err := Eval("1 + 2")
+	if err != nil {
+		t.Fatalf("Eval(\"1 + 2\") unexpected error: %v", err)
+	}
+	if got != 3 {
+		t.Errorf("Eval(\"1 + 2\") = %v; expected 3", got)
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		expr    string
+		wantErr error
+	}{
+		{"unbalanced open", "(1 + 2", ErrUnbalancedParens},
+		{"unbalanced close", "1 + 2)", ErrUnbalancedParens},
+		{"unknown token", "1 + @", ErrUnknownToken},
+		{"unknown identifier", "1 + y", ErrUnknownToken},
+		{"divide by zero", "1 / 0", ErrDivideByZero},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Eval(c.expr)
+			if !errors.Is(err, c.wantErr) {
+				t.Errorf("Eval(%q) error = %v; expected %v", c.expr, err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvalNaN(t *testing.T) {
+	got, err := Eval("(-1) ^ 0.5")
+	if err != nil {
+		t.Fatalf("Eval unexpected error: %v", err)
+	}
+	if !math.IsNaN(got) {
+		t.Errorf("Eval((-1) ^ 0.5) = %v; expected NaN", got)
+	}
+}
+
+func FuzzEval(f *testing.F) {
+	seeds := []string{
+		"2 + 3 * (4 - 1) / 2",
+		"-5 + 3",
+		"sqrt(16)",
+		"min(1, 2, 3)",
+		"x + y",
+		"(((1)))",
+		"1 / 0",
+		"",
+		"((",
+		"1 + + 2",
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, expr string) {
+		// Eval must never panic, regardless of the input; a malformed
+		// expression should surface as an error instead.
+		_, _ = Eval(expr, map[string]float64{"x": 1, "y": 2})
+	})
+}
@@ -0,0 +1,35 @@
+// example_test.go
+package calculator
+
+import "fmt"
+
+func ExampleAdd() {
+	fmt.Println(Add(2, 3))
+	// Output: 5
+}
+
+func ExampleDivide() {
+	result, err := Divide(10, 2)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(result)
+	// Output: 5
+}
+
+func ExampleDivide_byZero() {
+	_, err := Divide(1, 0)
+	fmt.Println(err)
+	// Output: calculator: division by zero
+}
+
+func ExampleCalculate() {
+	result, err := Calculate("mul", 6, 7)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(result)
+	// Output: 42
+}
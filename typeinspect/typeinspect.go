@@ -0,0 +1,210 @@
+// Package typeinspect walks arbitrary values with reflect and describes
+// their shape, for debugging and logging code that needs to handle values
+// of unknown type.
+package typeinspect
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Info describes the shape of a value as discovered via reflection. Only
+// the fields relevant to Kind are populated; the rest stay at their zero
+// value.
+type Info struct {
+	Kind    reflect.Kind
+	Type    reflect.Type
+	Len     int         // slices, arrays, maps, channels
+	Elem    *Info       // pointers, slices, arrays, channels, maps (value type)
+	Key     *Info       // maps (key type)
+	Fields  []FieldInfo // structs
+	Params  []Info      // funcs
+	Results []Info      // funcs
+	Cyclic  bool        // a pointer this value already contains itself through
+}
+
+// FieldInfo describes one field of a struct.
+type FieldInfo struct {
+	Name string
+	Info Info
+}
+
+// Describe walks v via reflection and returns a structural description of
+// its type, recursing into pointers, slices, arrays, maps, and struct
+// fields. Pointer cycles are detected by tracking the addresses on the
+// current recursion path (not every address ever visited), so a shared,
+// non-cyclic pointer reached twice through different fields is not
+// mistaken for a cycle.
+func Describe(v any) Info {
+	return describe(reflect.ValueOf(v), map[uintptr]bool{})
+}
+
+func describe(v reflect.Value, seen map[uintptr]bool) Info {
+	if !v.IsValid() {
+		return Info{Kind: reflect.Invalid}
+	}
+
+	info := Info{Kind: v.Kind(), Type: v.Type()}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return info
+		}
+		addr := v.Pointer()
+		if seen[addr] {
+			info.Cyclic = true
+			return info
+		}
+		seen[addr] = true
+		elem := describe(v.Elem(), seen)
+		delete(seen, addr)
+		info.Elem = &elem
+
+	case reflect.Slice, reflect.Array:
+		info.Len = v.Len()
+		elem := describe(reflect.Zero(v.Type().Elem()), seen)
+		info.Elem = &elem
+
+	case reflect.Map:
+		info.Len = v.Len()
+		key := describe(reflect.Zero(v.Type().Key()), seen)
+		elem := describe(reflect.Zero(v.Type().Elem()), seen)
+		info.Key = &key
+		info.Elem = &elem
+
+	case reflect.Chan:
+		info.Len = v.Len()
+		elem := describe(reflect.Zero(v.Type().Elem()), seen)
+		info.Elem = &elem
+
+	case reflect.Struct:
+		t := v.Type()
+		info.Fields = make([]FieldInfo, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			info.Fields[i] = FieldInfo{
+				Name: t.Field(i).Name,
+				Info: describe(v.Field(i), seen),
+			}
+		}
+
+	case reflect.Func:
+		t := v.Type()
+		info.Params = make([]Info, t.NumIn())
+		for i := 0; i < t.NumIn(); i++ {
+			info.Params[i] = describe(reflect.Zero(t.In(i)), seen)
+		}
+		info.Results = make([]Info, t.NumOut())
+		for i := 0; i < t.NumOut(); i++ {
+			info.Results[i] = describe(reflect.Zero(t.Out(i)), seen)
+		}
+	}
+
+	return info
+}
+
+// Formatter renders a single reflect.Value as a string. Register one with
+// RegisterKindFormatter or RegisterTypeFormatter to override how Format
+// renders a particular shape or concrete type.
+type Formatter interface {
+	Format(reflect.Value) string
+}
+
+// FormatterFunc adapts a plain function to the Formatter interface.
+type FormatterFunc func(reflect.Value) string
+
+// Format calls f.
+func (f FormatterFunc) Format(v reflect.Value) string {
+	return f(v)
+}
+
+var (
+	kindFormatters = map[reflect.Kind]Formatter{}
+	typeFormatters = map[reflect.Type]Formatter{}
+)
+
+// RegisterKindFormatter overrides how Format renders every value of the
+// given reflect.Kind.
+func RegisterKindFormatter(k reflect.Kind, f Formatter) {
+	kindFormatters[k] = f
+}
+
+// RegisterTypeFormatter overrides how Format renders every value of the
+// given concrete type. Type formatters take precedence over kind
+// formatters.
+func RegisterTypeFormatter(t reflect.Type, f Formatter) {
+	typeFormatters[t] = f
+}
+
+// Format renders v as a human-readable string, consulting any formatter
+// registered for v's concrete type or reflect.Kind before falling back to
+// a built-in default that recurses into pointers, collections, and
+// structs. Like Describe, it tracks pointer addresses only along the
+// current recursion path, so a shared (non-cyclic) pointer reached twice
+// renders normally both times.
+func Format(v any) string {
+	return format(reflect.ValueOf(v), map[uintptr]bool{})
+}
+
+func format(v reflect.Value, seen map[uintptr]bool) string {
+	if !v.IsValid() {
+		return "<invalid>"
+	}
+	if f, ok := typeFormatters[v.Type()]; ok {
+		return f.Format(v)
+	}
+	if f, ok := kindFormatters[v.Kind()]; ok {
+		return f.Format(v)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "nil"
+		}
+		addr := v.Pointer()
+		if seen[addr] {
+			return "<cycle>"
+		}
+		seen[addr] = true
+		rendered := "&" + format(v.Elem(), seen)
+		delete(seen, addr)
+		return rendered
+
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			parts[i] = format(v.Index(i), seen)
+		}
+		return fmt.Sprintf("%s{%s}", v.Type(), strings.Join(parts, ", "))
+
+	case reflect.Map:
+		parts := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			parts = append(parts, fmt.Sprintf("%s: %s", format(iter.Key(), seen), format(iter.Value(), seen)))
+		}
+		return fmt.Sprintf("%s{%s}", v.Type(), strings.Join(parts, ", "))
+
+	case reflect.Struct:
+		t := v.Type()
+		parts := make([]string, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			parts[i] = fmt.Sprintf("%s: %s", t.Field(i).Name, format(v.Field(i), seen))
+		}
+		return fmt.Sprintf("%s{%s}", t, strings.Join(parts, ", "))
+
+	case reflect.Chan:
+		return fmt.Sprintf("%s(len=%d)", v.Type(), v.Len())
+
+	case reflect.Func:
+		return v.Type().String()
+
+	default:
+		if !v.CanInterface() {
+			return fmt.Sprintf("<unexported %s>", v.Type())
+		}
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
@@ -0,0 +1,196 @@
+package typeinspect
+
+import (
+	"reflect"
+	"testing"
+)
+
+type point struct {
+	X, Y int
+}
+
+type node struct {
+	Value int
+	Next  *node
+}
+
+type diamond struct {
+	A, B *node
+}
+
+func TestDescribeScalars(t *testing.T) {
+	cases := []struct {
+		name string
+		v    any
+		want reflect.Kind
+	}{
+		{"int", 42, reflect.Int},
+		{"int8", int8(1), reflect.Int8},
+		{"uint64", uint64(1), reflect.Uint64},
+		{"float32", float32(1.5), reflect.Float32},
+		{"complex128", complex(1, 2), reflect.Complex128},
+		{"string", "hi", reflect.String},
+		{"bool", true, reflect.Bool},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			info := Describe(c.v)
+			if info.Kind != c.want {
+				t.Errorf("Describe(%v).Kind = %v; expected %v", c.v, info.Kind, c.want)
+			}
+		})
+	}
+}
+
+func TestDescribeSlice(t *testing.T) {
+	info := Describe([]int{1, 2, 3})
+	if info.Kind != reflect.Slice {
+		t.Fatalf("Kind = %v; expected Slice", info.Kind)
+	}
+	if info.Len != 3 {
+		t.Errorf("Len = %d; expected 3", info.Len)
+	}
+	if info.Elem == nil || info.Elem.Kind != reflect.Int {
+		t.Errorf("Elem = %+v; expected Int", info.Elem)
+	}
+}
+
+func TestDescribeMap(t *testing.T) {
+	info := Describe(map[string]int{"a": 1})
+	if info.Kind != reflect.Map {
+		t.Fatalf("Kind = %v; expected Map", info.Kind)
+	}
+	if info.Key == nil || info.Key.Kind != reflect.String {
+		t.Errorf("Key = %+v; expected String", info.Key)
+	}
+	if info.Elem == nil || info.Elem.Kind != reflect.Int {
+		t.Errorf("Elem = %+v; expected Int", info.Elem)
+	}
+}
+
+func TestDescribeStruct(t *testing.T) {
+	info := Describe(point{X: 1, Y: 2})
+	if info.Kind != reflect.Struct {
+		t.Fatalf("Kind = %v; expected Struct", info.Kind)
+	}
+	if len(info.Fields) != 2 || info.Fields[0].Name != "X" || info.Fields[1].Name != "Y" {
+		t.Errorf("Fields = %+v; expected X, Y", info.Fields)
+	}
+}
+
+func TestDescribePointer(t *testing.T) {
+	x := 5
+	info := Describe(&x)
+	if info.Kind != reflect.Ptr {
+		t.Fatalf("Kind = %v; expected Ptr", info.Kind)
+	}
+	if info.Elem == nil || info.Elem.Kind != reflect.Int {
+		t.Errorf("Elem = %+v; expected Int", info.Elem)
+	}
+}
+
+func TestDescribeCyclicPointer(t *testing.T) {
+	n := &node{Value: 1}
+	n.Next = n
+
+	info := Describe(n)
+	if info.Kind != reflect.Ptr {
+		t.Fatalf("Kind = %v; expected Ptr", info.Kind)
+	}
+	nextField := info.Elem.Fields[1]
+	if nextField.Name != "Next" {
+		t.Fatalf("field 1 = %q; expected Next", nextField.Name)
+	}
+	if !nextField.Info.Cyclic {
+		t.Errorf("Next.Cyclic = false; expected true for a self-referencing pointer")
+	}
+}
+
+func TestDescribeSharedPointerNotCyclic(t *testing.T) {
+	shared := &node{Value: 1}
+	d := diamond{A: shared, B: shared}
+
+	info := Describe(d)
+	if info.Kind != reflect.Struct {
+		t.Fatalf("Kind = %v; expected Struct", info.Kind)
+	}
+	for _, field := range info.Fields {
+		if field.Info.Cyclic {
+			t.Errorf("%s.Cyclic = true; expected false for a shared, non-cyclic pointer", field.Name)
+		}
+	}
+}
+
+func TestDescribeFunc(t *testing.T) {
+	info := Describe(func(a int, b string) bool { return true })
+	if info.Kind != reflect.Func {
+		t.Fatalf("Kind = %v; expected Func", info.Kind)
+	}
+	if len(info.Params) != 2 || info.Params[0].Kind != reflect.Int || info.Params[1].Kind != reflect.String {
+		t.Errorf("Params = %+v; expected [Int String]", info.Params)
+	}
+	if len(info.Results) != 1 || info.Results[0].Kind != reflect.Bool {
+		t.Errorf("Results = %+v; expected [Bool]", info.Results)
+	}
+}
+
+func TestFormatScalarsAndCollections(t *testing.T) {
+	cases := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{"int", 42, "42"},
+		{"string", "hi", "hi"},
+		{"slice", []int{1, 2}, "[]int{1, 2}"},
+		{"struct", point{X: 1, Y: 2}, "typeinspect.point{X: 1, Y: 2}"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Format(c.v); got != c.want {
+				t.Errorf("Format(%v) = %q; expected %q", c.v, got, c.want)
+			}
+		})
+	}
+}
+
+func TestFormatPointerCycle(t *testing.T) {
+	n := &node{Value: 1}
+	n.Next = n
+
+	got := Format(n)
+	if !contains(got, "<cycle>") {
+		t.Errorf("Format(cyclic) = %q; expected it to mention <cycle>", got)
+	}
+}
+
+func TestFormatSharedPointerNotCyclic(t *testing.T) {
+	shared := &node{Value: 1}
+	d := diamond{A: shared, B: shared}
+
+	got := Format(d)
+	if contains(got, "<cycle>") {
+		t.Errorf("Format(shared) = %q; expected no <cycle> for a shared, non-cyclic pointer", got)
+	}
+}
+
+func TestRegisterTypeFormatter(t *testing.T) {
+	RegisterTypeFormatter(reflect.TypeOf(point{}), FormatterFunc(func(v reflect.Value) string {
+		return "POINT"
+	}))
+
+	if got := Format(point{X: 1, Y: 2}); got != "POINT" {
+		t.Errorf("Format(point) = %q; expected POINT", got)
+	}
+}
+
+func contains(s, substr string) bool {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if s[i:i+len(substr)] == substr {
+			return true
+		}
+	}
+	return false
+}